@@ -0,0 +1,205 @@
+// Hand-written to mirror what protoc-gen-go-grpc would emit from proto/cart.proto.
+// Regenerate for real with proto/generate.sh once protoc is available
+// in this environment; this will otherwise silently drift from the
+// .proto definition.
+
+package cartpb
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+const (
+	CartService_Add_FullMethodName    = "/cart.CartService/Add"
+	CartService_Update_FullMethodName = "/cart.CartService/Update"
+	CartService_Remove_FullMethodName = "/cart.CartService/Remove"
+	CartService_List_FullMethodName   = "/cart.CartService/List"
+	CartService_Sum_FullMethodName    = "/cart.CartService/Sum"
+)
+
+// CartServiceClient is the client API for CartService.
+type CartServiceClient interface {
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*Cart, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Cart, error)
+	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*Cart, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Cart, error)
+	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
+}
+
+type cartServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCartServiceClient(cc grpc.ClientConnInterface) CartServiceClient {
+	return &cartServiceClient{cc}
+}
+
+func (c *cartServiceClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	if err := c.cc.Invoke(ctx, CartService_Add_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	if err := c.cc.Invoke(ctx, CartService_Update_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	if err := c.cc.Invoke(ctx, CartService_Remove_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	if err := c.cc.Invoke(ctx, CartService_List_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error) {
+	out := new(SumResponse)
+	if err := c.cc.Invoke(ctx, CartService_Sum_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CartServiceServer is the server API for CartService.
+type CartServiceServer interface {
+	Add(context.Context, *AddRequest) (*Cart, error)
+	Update(context.Context, *UpdateRequest) (*Cart, error)
+	Remove(context.Context, *RemoveRequest) (*Cart, error)
+	List(context.Context, *ListRequest) (*Cart, error)
+	Sum(context.Context, *SumRequest) (*SumResponse, error)
+}
+
+// UnimplementedCartServiceServer can be embedded to have forward
+// compatible implementations.
+type UnimplementedCartServiceServer struct{}
+
+func (UnimplementedCartServiceServer) Add(context.Context, *AddRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method Add not implemented")
+}
+
+func (UnimplementedCartServiceServer) Update(context.Context, *UpdateRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method Update not implemented")
+}
+
+func (UnimplementedCartServiceServer) Remove(context.Context, *RemoveRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method Remove not implemented")
+}
+
+func (UnimplementedCartServiceServer) List(context.Context, *ListRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method List not implemented")
+}
+
+func (UnimplementedCartServiceServer) Sum(context.Context, *SumRequest) (*SumResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Sum not implemented")
+}
+
+func RegisterCartServiceServer(s grpc.ServiceRegistrar, srv CartServiceServer) {
+	s.RegisterService(&CartService_ServiceDesc, srv)
+}
+
+func _CartService_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CartService_Add_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CartService_Update_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CartService_Remove_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Remove(ctx, req.(*RemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CartService_List_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Sum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CartService_Sum_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Sum(ctx, req.(*SumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var CartService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cart.CartService",
+	HandlerType: (*CartServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Add", Handler: _CartService_Add_Handler},
+		{MethodName: "Update", Handler: _CartService_Update_Handler},
+		{MethodName: "Remove", Handler: _CartService_Remove_Handler},
+		{MethodName: "List", Handler: _CartService_List_Handler},
+		{MethodName: "Sum", Handler: _CartService_Sum_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/cart.proto",
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ivanrbnc/k8s-ecommerce/cart-service/cartpb"
+	"github.com/ivanrbnc/k8s-ecommerce/cart-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+)
+
+// grpcServer adapts the shared cart service to the generated CartService
+// gRPC interface.
+type grpcServer struct {
+	cartpb.UnimplementedCartServiceServer
+	svc *service.Service
+}
+
+func newGRPCServer(svc *service.Service) *grpcServer {
+	return &grpcServer{svc: svc}
+}
+
+func (s *grpcServer) Add(ctx context.Context, req *cartpb.AddRequest) (*cartpb.Cart, error) {
+	if !auth.RequireOwnerOrRole(ctx, req.UserId, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	cart, err := s.svc.Add(ctx, req.UserId, toItem(req.Item))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	updateCartItemsGauge()
+	return toCartPB(cart), nil
+}
+
+func (s *grpcServer) Update(ctx context.Context, req *cartpb.UpdateRequest) (*cartpb.Cart, error) {
+	if !auth.RequireOwnerOrRole(ctx, req.UserId, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	cart, err := s.svc.Update(ctx, req.UserId, toItem(req.Item))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	updateCartItemsGauge()
+	return toCartPB(cart), nil
+}
+
+func (s *grpcServer) Remove(ctx context.Context, req *cartpb.RemoveRequest) (*cartpb.Cart, error) {
+	if !auth.RequireOwnerOrRole(ctx, req.UserId, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	cart, err := s.svc.Remove(ctx, req.UserId, int(req.ProductId))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	updateCartItemsGauge()
+	return toCartPB(cart), nil
+}
+
+func (s *grpcServer) List(ctx context.Context, req *cartpb.ListRequest) (*cartpb.Cart, error) {
+	if !auth.RequireOwnerOrRole(ctx, req.UserId, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	cart, err := s.svc.Get(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toCartPB(cart), nil
+}
+
+func (s *grpcServer) Sum(ctx context.Context, req *cartpb.SumRequest) (*cartpb.SumResponse, error) {
+	if !auth.RequireOwnerOrRole(ctx, req.UserId, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	total, err := s.svc.Sum(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &cartpb.SumResponse{Total: total}, nil
+}
+
+func toItem(item *cartpb.CartItem) service.Item {
+	if item == nil {
+		return service.Item{}
+	}
+	return service.Item{ProductID: int(item.ProductId), Quantity: int(item.Quantity)}
+}
+
+func toCartPB(cart service.Cart) *cartpb.Cart {
+	items := make([]*cartpb.CartItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, &cartpb.CartItem{ProductId: int32(item.ProductID), Quantity: int32(item.Quantity)})
+	}
+	return &cartpb.Cart{UserId: cart.UserID, Items: items}
+}
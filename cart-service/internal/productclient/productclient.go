@@ -0,0 +1,58 @@
+// Package productclient adapts the generated product gRPC client to the
+// cart service's ProductPricer interface.
+package productclient
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/ivanrbnc/k8s-ecommerce/cart-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/product-service/productpb"
+)
+
+type Client struct {
+	grpcClient productpb.ProductServiceClient
+}
+
+func Dial(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{grpcClient: productpb.NewProductServiceClient(conn)}, nil
+}
+
+func (c *Client) PriceByID(ctx context.Context, productID int) (float64, error) {
+	product, err := c.get(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+	return product.Price, nil
+}
+
+func (c *Client) StockByID(ctx context.Context, productID int) (int, error) {
+	product, err := c.get(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+	return int(product.Stock), nil
+}
+
+func (c *Client) get(ctx context.Context, productID int) (*productpb.Product, error) {
+	product, err := c.grpcClient.GetByID(ctx, &productpb.GetProductByIDRequest{Id: int32(productID)})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return nil, service.ErrProductNotFound
+		}
+		return nil, err
+	}
+	return product, nil
+}
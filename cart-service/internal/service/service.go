@@ -0,0 +1,194 @@
+// Package service holds the cart business logic shared by the HTTP and
+// gRPC handlers.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNotFound is returned when a cart does not exist for the given user.
+var ErrNotFound = errors.New("cart not found")
+
+// ErrProductNotFound is returned when an item references a product the
+// product service doesn't know about.
+var ErrProductNotFound = errors.New("product not found")
+
+// ErrInsufficientStock is returned when an item's quantity exceeds the
+// product's current stock.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+type Item struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
+
+type Cart struct {
+	UserID string `json:"user_id"`
+	Items  []Item `json:"items"`
+}
+
+// ProductPricer resolves the current price and stock of a product so
+// the cart service can compute totals and validate adds without owning
+// product data itself.
+type ProductPricer interface {
+	PriceByID(ctx context.Context, productID int) (float64, error)
+	StockByID(ctx context.Context, productID int) (int, error)
+}
+
+type Service struct {
+	rdb    *redis.Client
+	pricer ProductPricer
+}
+
+func New(rdb *redis.Client, pricer ProductPricer) *Service {
+	return &Service{rdb: rdb, pricer: pricer}
+}
+
+func (s *Service) Get(ctx context.Context, userID string) (Cart, error) {
+	cartData, err := s.rdb.Get(ctx, "cart:"+userID).Result()
+	if err == redis.Nil {
+		return Cart{UserID: userID, Items: []Item{}}, nil
+	} else if err != nil {
+		return Cart{}, err
+	}
+
+	var cart Cart
+	if err := json.Unmarshal([]byte(cartData), &cart); err != nil {
+		return Cart{}, err
+	}
+	return cart, nil
+}
+
+func (s *Service) Add(ctx context.Context, userID string, item Item) (Cart, error) {
+	stock, err := s.pricer.StockByID(ctx, item.ProductID)
+	if err != nil {
+		return Cart{}, err
+	}
+
+	cart, err := s.Get(ctx, userID)
+	if err != nil {
+		return Cart{}, err
+	}
+
+	found := false
+	for i, existing := range cart.Items {
+		if existing.ProductID == item.ProductID {
+			if existing.Quantity+item.Quantity > stock {
+				return Cart{}, ErrInsufficientStock
+			}
+			cart.Items[i].Quantity += item.Quantity
+			found = true
+			break
+		}
+	}
+	if !found {
+		if item.Quantity > stock {
+			return Cart{}, ErrInsufficientStock
+		}
+		cart.Items = append(cart.Items, item)
+	}
+
+	return cart, s.save(ctx, cart)
+}
+
+func (s *Service) Update(ctx context.Context, userID string, item Item) (Cart, error) {
+	cart, err := s.Get(ctx, userID)
+	if err != nil {
+		return Cart{}, err
+	}
+
+	found := false
+	for i, existing := range cart.Items {
+		if existing.ProductID == item.ProductID {
+			cart.Items[i].Quantity = item.Quantity
+			found = true
+			break
+		}
+	}
+	if !found {
+		cart.Items = append(cart.Items, item)
+	}
+
+	return cart, s.save(ctx, cart)
+}
+
+func (s *Service) Remove(ctx context.Context, userID string, productID int) (Cart, error) {
+	cart, err := s.Get(ctx, userID)
+	if err != nil {
+		return Cart{}, err
+	}
+
+	items := make([]Item, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		if item.ProductID != productID {
+			items = append(items, item)
+		}
+	}
+	cart.Items = items
+
+	return cart, s.save(ctx, cart)
+}
+
+func (s *Service) Clear(ctx context.Context, userID string) error {
+	return s.rdb.Del(ctx, "cart:"+userID).Err()
+}
+
+// Sum totals the cart using authoritative prices from the product
+// service rather than any client-supplied amount.
+func (s *Service) Sum(ctx context.Context, userID string) (float64, error) {
+	cart, err := s.Get(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, item := range cart.Items {
+		price, err := s.pricer.PriceByID(ctx, item.ProductID)
+		if err != nil {
+			return 0, err
+		}
+		total += price * float64(item.Quantity)
+	}
+	return total, nil
+}
+
+// TotalItems sums the item quantity across every cart in Redis, for the
+// cart_items_total gauge. It scans rather than keeping a running counter
+// so the value stays correct regardless of which cart-service replica
+// last touched a given cart.
+func (s *Service) TotalItems(ctx context.Context) (int, error) {
+	var total int
+	iter := s.rdb.Scan(ctx, 0, "cart:*", 0).Iterator()
+	for iter.Next(ctx) {
+		cartData, err := s.rdb.Get(ctx, iter.Val()).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+
+		var cart Cart
+		if err := json.Unmarshal([]byte(cartData), &cart); err != nil {
+			return 0, err
+		}
+		for _, item := range cart.Items {
+			total += item.Quantity
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (s *Service) save(ctx context.Context, cart Cart) error {
+	cartJSON, err := json.Marshal(cart)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, "cart:"+cart.UserID, cartJSON, 0).Err()
+}
@@ -3,30 +3,41 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
+	"net"
 	"net/http"
 	"os"
 
 	"github.com/gorilla/mux"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	"github.com/ivanrbnc/k8s-ecommerce/cart-service/cartpb"
+	"github.com/ivanrbnc/k8s-ecommerce/cart-service/internal/productclient"
+	"github.com/ivanrbnc/k8s-ecommerce/cart-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+	"github.com/ivanrbnc/k8s-ecommerce/internal/observability"
 )
 
-type CartItem struct {
-	ProductID int `json:"product_id"`
-	Quantity  int `json:"quantity"`
-}
-
-type Cart struct {
-	UserID string     `json:"user_id"`
-	Items  []CartItem `json:"items"`
-}
+const serviceName = "cart-service"
 
 var (
 	rdb *redis.Client
 	ctx = context.Background()
+	svc *service.Service
 )
 
 func main() {
+	observability.SetupLogging(serviceName)
+
+	shutdownTracing, err := observability.InitTracing(ctx, serviceName)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(ctx)
+
 	// Connect to Redis
 	redisAddr := os.Getenv("REDIS_ADDR")
 	if redisAddr == "" {
@@ -39,19 +50,60 @@ func main() {
 	})
 
 	// Test Redis connection
-	_, err := rdb.Ping(ctx).Result()
+	_, err = rdb.Ping(ctx).Result()
 	if err != nil {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
 	log.Println("Connected to Redis successfully")
 
+	productServiceAddr := os.Getenv("PRODUCT_SERVICE_GRPC_ADDR")
+	if productServiceAddr == "" {
+		productServiceAddr = "product-service:9001"
+	}
+	pricer, err := productclient.Dial(productServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to product service: %v", err)
+	}
+
+	svc = service.New(rdb, pricer)
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "dev-secret"
+	}
+
 	router := mux.NewRouter()
+	router.Use(observability.Middleware(serviceName))
 
 	router.HandleFunc("/health", healthHandler).Methods("GET")
-	router.HandleFunc("/cart/{userId}", getCartHandler).Methods("GET")
-	router.HandleFunc("/cart/{userId}/add", addToCartHandler).Methods("POST")
-	router.HandleFunc("/cart/{userId}/remove", removeFromCartHandler).Methods("POST")
-	router.HandleFunc("/cart/{userId}/clear", clearCartHandler).Methods("DELETE")
+	router.Handle("/metrics", observability.Handler()).Methods("GET")
+
+	cartRouter := router.PathPrefix("/cart/{userId}").Subrouter()
+	cartRouter.Use(auth.Middleware(jwtSecret))
+	cartRouter.Use(requireOwnership)
+	cartRouter.HandleFunc("", getCartHandler).Methods("GET")
+	cartRouter.HandleFunc("/add", addToCartHandler).Methods("POST")
+	cartRouter.HandleFunc("/remove", removeFromCartHandler).Methods("POST")
+	cartRouter.HandleFunc("/clear", clearCartHandler).Methods("DELETE")
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9002"
+	}
+
+	go func() {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+		}
+		grpcServer := grpc.NewServer(
+			grpc.UnaryInterceptor(auth.UnaryServerInterceptor(jwtSecret)),
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		)
+		cartpb.RegisterCartServiceServer(grpcServer, newGRPCServer(svc))
+		log.Printf("Cart Service gRPC server starting on port %s...", grpcPort)
+		log.Fatal(grpcServer.Serve(lis))
+	}()
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -62,6 +114,22 @@ func main() {
 	log.Fatal(http.ListenAndServe(":"+port, router))
 }
 
+// requireOwnership rejects requests where the authenticated caller's
+// user ID doesn't match the {userId} path segment, so no one can read
+// or mutate another user's cart by guessing their ID.
+func requireOwnership(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		if auth.UserID(r.Context()) != vars["userId"] {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Forbidden"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -83,24 +151,13 @@ func getCartHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["userId"]
 
-	cartData, err := rdb.Get(ctx, "cart:"+userID).Result()
-	if err == redis.Nil {
-		// Cart doesn't exist, return empty cart
-		json.NewEncoder(w).Encode(Cart{UserID: userID, Items: []CartItem{}})
-		return
-	} else if err != nil {
+	cart, err := svc.Get(ctx, userID)
+	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve cart"})
 		return
 	}
 
-	var cart Cart
-	if err := json.Unmarshal([]byte(cartData), &cart); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to parse cart"})
-		return
-	}
-
 	json.NewEncoder(w).Encode(cart)
 }
 
@@ -109,57 +166,30 @@ func addToCartHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["userId"]
 
-	var newItem CartItem
+	var newItem service.Item
 	if err := json.NewDecoder(r.Body).Decode(&newItem); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
 		return
 	}
 
-	// Get existing cart
-	var cart Cart
-	cartData, err := rdb.Get(ctx, "cart:"+userID).Result()
-	if err == redis.Nil {
-		cart = Cart{UserID: userID, Items: []CartItem{}}
-	} else if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve cart"})
-		return
-	} else {
-		if err := json.Unmarshal([]byte(cartData), &cart); err != nil {
+	cart, err := svc.Add(ctx, userID, newItem)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProductNotFound):
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Product does not exist"})
+		case errors.Is(err, service.ErrInsufficientStock):
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Requested quantity exceeds available stock"})
+		default:
 			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to parse cart"})
-			return
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to save cart"})
 		}
-	}
-
-	// Check if item already exists in cart
-	found := false
-	for i, item := range cart.Items {
-		if item.ProductID == newItem.ProductID {
-			cart.Items[i].Quantity += newItem.Quantity
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		cart.Items = append(cart.Items, newItem)
-	}
-
-	// Save cart back to Redis
-	cartJSON, err := json.Marshal(cart)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to save cart"})
 		return
 	}
 
-	if err := rdb.Set(ctx, "cart:"+userID, cartJSON, 0).Err(); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to save cart"})
-		return
-	}
+	updateCartItemsGauge()
 
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(cart)
@@ -179,49 +209,28 @@ func removeFromCartHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get existing cart
-	cartData, err := rdb.Get(ctx, "cart:"+userID).Result()
-	if err == redis.Nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Cart not found"})
-		return
-	} else if err != nil {
+	cart, err := svc.Remove(ctx, userID, removeItem.ProductID)
+	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve cart"})
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to save cart"})
 		return
 	}
 
-	var cart Cart
-	if err := json.Unmarshal([]byte(cartData), &cart); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to parse cart"})
-		return
-	}
+	updateCartItemsGauge()
 
-	// Remove item from cart
-	newItems := []CartItem{}
-	for _, item := range cart.Items {
-		if item.ProductID != removeItem.ProductID {
-			newItems = append(newItems, item)
-		}
-	}
-	cart.Items = newItems
+	json.NewEncoder(w).Encode(cart)
+}
 
-	// Save cart back to Redis
-	cartJSON, err := json.Marshal(cart)
+// updateCartItemsGauge refreshes cart_items_total with the current item
+// count summed across every cart. Failures are logged rather than
+// returned since the caller has already responded to its own request.
+func updateCartItemsGauge() {
+	total, err := svc.TotalItems(ctx)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to save cart"})
+		log.Printf("failed to refresh cart_items_total: %v", err)
 		return
 	}
-
-	if err := rdb.Set(ctx, "cart:"+userID, cartJSON, 0).Err(); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to save cart"})
-		return
-	}
-
-	json.NewEncoder(w).Encode(cart)
+	observability.CartItemsTotal.Set(float64(total))
 }
 
 func clearCartHandler(w http.ResponseWriter, r *http.Request) {
@@ -229,11 +238,13 @@ func clearCartHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["userId"]
 
-	if err := rdb.Del(ctx, "cart:"+userID).Err(); err != nil {
+	if err := svc.Clear(ctx, userID); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to clear cart"})
 		return
 	}
 
+	updateCartItemsGauge()
+
 	json.NewEncoder(w).Encode(map[string]string{"message": "Cart cleared successfully"})
 }
@@ -0,0 +1,62 @@
+package observability
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests handled, labeled by service, route, method, and status.",
+	}, []string{"service", "route", "method", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "http_request_duration_seconds",
+		Help: "HTTP request latency in seconds, labeled by service, route, and method.",
+	}, []string{"service", "route", "method"})
+
+	// CartItemsTotal tracks the item count summed across every cart;
+	// cart-service sets it after every add/remove/clear. Deliberately
+	// not labeled by user_id: unlike product_stock, the user count is
+	// unbounded and would blow up series cardinality.
+	CartItemsTotal = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cart_items_total",
+		Help: "Total number of items across all carts.",
+	})
+
+	// OrdersCreatedTotal counts orders successfully created; order-service
+	// increments it once per committed order.
+	OrdersCreatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "orders_created_total",
+		Help: "Total number of orders successfully created.",
+	})
+
+	// ProductStock tracks current stock per product, labeled by
+	// product_id; product-service sets it whenever a product's stock
+	// changes.
+	ProductStock = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "product_stock",
+		Help: "Current stock for a product.",
+	}, []string{"product_id"})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration, CartItemsTotal, OrdersCreatedTotal, ProductStock)
+}
+
+// RecordRequest records one HTTP request's outcome for
+// http_requests_total and http_request_duration_seconds.
+func RecordRequest(service, route, method, status string, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(service, route, method, status).Inc()
+	httpRequestDuration.WithLabelValues(service, route, method).Observe(duration.Seconds())
+}
+
+// Handler serves the Prometheus exposition format for scraping at
+// /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,70 @@
+package observability
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Middleware generates or propagates an X-Request-ID header, extracts
+// any incoming W3C traceparent into a span covering the request, and
+// records http_requests_total/http_request_duration_seconds plus a
+// structured access log line. Apply it with router.Use on each
+// service's top-level mux.Router so every request that matches a route
+// is covered; mux does not run router middleware for unmatched requests.
+func Middleware(serviceName string) mux.MiddlewareFunc {
+	tracer := otel.Tracer(serviceName)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = newRequestID()
+			}
+			w.Header().Set("X-Request-ID", requestID)
+
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx = withRequestID(ctx, requestID)
+			ctx, _ = withLogFields(ctx)
+
+			route := routeTemplate(r)
+			ctx, span := tracer.Start(ctx, r.Method+" "+route)
+			defer span.End()
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+			next.ServeHTTP(rec, r.WithContext(ctx))
+			duration := time.Since(start)
+
+			status := strconv.Itoa(rec.status)
+			RecordRequest(serviceName, route, r.Method, status, duration)
+			Logger(ctx, route).Info("handled request",
+				"method", r.Method,
+				"status", rec.status,
+				"duration_ms", duration.Milliseconds(),
+			)
+		})
+	}
+}
+
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return r.URL.Path
+}
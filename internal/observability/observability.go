@@ -0,0 +1,102 @@
+// Package observability provides the structured logging, Prometheus
+// metrics, and distributed tracing primitives shared by every service's
+// HTTP mux: SetupLogging configures slog, InitTracing wires an OTLP
+// trace exporter with W3C propagation, and Middleware ties both
+// together with request ID generation/propagation and per-request
+// metrics recording.
+package observability
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// SetupLogging configures the default slog logger to emit JSON, with
+// every record tagged with the owning service's name.
+func SetupLogging(serviceName string) {
+	handler := slog.NewJSONHandler(os.Stdout, nil)
+	slog.SetDefault(slog.New(handler).With("service", serviceName))
+}
+
+type ctxKey int
+
+const requestIDKey ctxKey = iota
+
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID stashed in ctx by Middleware, or ""
+// if Middleware was never applied.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// logFields is a mutable bag Middleware attaches to the request
+// context before calling the handler chain. auth.Middleware, which
+// runs nested inside it on a subrouter, fills in the user ID via
+// SetUserID once it authenticates the caller. A plain context.WithValue
+// can't carry that back out to Middleware's own access-log line once
+// next.ServeHTTP returns, since that value is set on a context derived
+// further down the chain - but everyone holds the same *logFields
+// pointer, so mutating it through SetUserID is visible to whoever
+// attached it.
+type logFields struct {
+	mu     sync.Mutex
+	userID string
+}
+
+func (f *logFields) setUserID(userID string) {
+	f.mu.Lock()
+	f.userID = userID
+	f.mu.Unlock()
+}
+
+func (f *logFields) getUserID() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.userID
+}
+
+type logFieldsKey struct{}
+
+func withLogFields(ctx context.Context) (context.Context, *logFields) {
+	f := &logFields{}
+	return context.WithValue(ctx, logFieldsKey{}, f), f
+}
+
+// SetUserID records the authenticated caller's user ID against the
+// in-flight request's access-log line. Middleware must be in the
+// handler chain for this to have any effect; it's a no-op otherwise.
+func SetUserID(ctx context.Context, userID string) {
+	if f, ok := ctx.Value(logFieldsKey{}).(*logFields); ok {
+		f.setUserID(userID)
+	}
+}
+
+// Logger returns a logger scoped to the current request, tagged with
+// route, request_id, and (when the caller has been authenticated by
+// auth.Middleware earlier in the chain) user_id.
+func Logger(ctx context.Context, route string) *slog.Logger {
+	l := slog.Default().With("route", route)
+	if id := RequestID(ctx); id != "" {
+		l = l.With("request_id", id)
+	}
+	if f, ok := ctx.Value(logFieldsKey{}).(*logFields); ok {
+		if uid := f.getUserID(); uid != "" {
+			l = l.With("user_id", uid)
+		}
+	}
+	return l
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+	"github.com/ivanrbnc/k8s-ecommerce/internal/observability"
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/orderpb"
+)
+
+// grpcServer adapts the shared order service to the generated
+// OrderService gRPC interface.
+type grpcServer struct {
+	orderpb.UnimplementedOrderServiceServer
+	svc *service.Service
+}
+
+func newGRPCServer(svc *service.Service) *grpcServer {
+	return &grpcServer{svc: svc}
+}
+
+func (s *grpcServer) Create(ctx context.Context, req *orderpb.CreateOrderRequest) (*orderpb.Order, error) {
+	if !auth.RequireOwnerOrRole(ctx, req.UserId, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	items := make([]service.Item, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, service.Item{ProductID: int(item.ProductId), Quantity: int(item.Quantity)})
+	}
+
+	order, err := s.svc.Create(ctx, req.UserId, items)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	observability.OrdersCreatedTotal.Inc()
+	return toOrderPB(order)
+}
+
+func toGRPCError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrProductNotFound):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrEmptyOrder):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func (s *grpcServer) Get(ctx context.Context, req *orderpb.GetOrderRequest) (*orderpb.Order, error) {
+	order, err := s.svc.Get(int(req.OrderId))
+	if errors.Is(err, service.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if !auth.RequireOwnerOrRole(ctx, order.UserID, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	return toOrderPB(order)
+}
+
+func (s *grpcServer) ListByUser(ctx context.Context, req *orderpb.ListByUserRequest) (*orderpb.ListByUserResponse, error) {
+	if !auth.RequireOwnerOrRole(ctx, req.UserId, auth.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	orders, err := s.svc.ListByUser(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &orderpb.ListByUserResponse{Orders: make([]*orderpb.Order, 0, len(orders))}
+	for _, order := range orders {
+		pb, err := toOrderPB(order)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Orders = append(resp.Orders, pb)
+	}
+	return resp, nil
+}
+
+func toOrderPB(order service.Order) (*orderpb.Order, error) {
+	createdAt, err := ptypes.TimestampProto(order.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*orderpb.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &orderpb.OrderItem{ProductId: int32(item.ProductID), Quantity: int32(item.Quantity)})
+	}
+
+	return &orderpb.Order{
+		Id:        int32(order.ID),
+		UserId:    order.UserID,
+		Items:     items,
+		Total:     order.Total,
+		Status:    order.Status,
+		CreatedAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,119 @@
+// Package productclient talks to the product service on behalf of the
+// order service's checkout flow: gRPC for reading authoritative prices,
+// and the product service's reserve/release HTTP endpoints for the
+// stock hold that backs order creation.
+package productclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/product-service/productpb"
+)
+
+// serviceTokenTTL is how long the token minted for each reserve/release
+// call is valid. It's only ever used for the single request it's
+// attached to.
+const serviceTokenTTL = time.Minute
+
+type Client struct {
+	grpcClient productpb.ProductServiceClient
+	httpAddr   string
+	httpClient *http.Client
+	jwtSecret  string
+}
+
+// Dial connects to the product service's gRPC port for reads and
+// records its HTTP base address for the reserve/release calls, which
+// are admin-gated and so are signed with jwtSecret on each request.
+func Dial(grpcAddr, httpAddr, jwtSecret string) (*Client, error) {
+	conn, err := grpc.NewClient(grpcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		grpcClient: productpb.NewProductServiceClient(conn),
+		httpAddr:   httpAddr,
+		httpClient: &http.Client{},
+		jwtSecret:  jwtSecret,
+	}, nil
+}
+
+func (c *Client) PriceByID(ctx context.Context, productID int) (float64, error) {
+	product, err := c.grpcClient.GetByID(ctx, &productpb.GetProductByIDRequest{Id: int32(productID)})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return 0, service.ErrProductNotFound
+		}
+		return 0, err
+	}
+	return product.Price, nil
+}
+
+// Reserve atomically decrements stock on the product service. It is the
+// first phase of the checkout flow's reserve/commit/release cycle.
+func (c *Client) Reserve(ctx context.Context, productID, quantity int) error {
+	return c.postStockChange(ctx, productID, quantity, "reserve")
+}
+
+// Release restores stock reserved for an order that failed to commit.
+func (c *Client) Release(ctx context.Context, productID, quantity int) error {
+	return c.postStockChange(ctx, productID, quantity, "release")
+}
+
+func (c *Client) postStockChange(ctx context.Context, productID, quantity int, action string) error {
+	body, err := json.Marshal(struct {
+		Quantity int `json:"quantity"`
+	}{Quantity: quantity})
+	if err != nil {
+		return err
+	}
+
+	token, err := auth.GenerateToken(c.jwtSecret, "order-service", auth.RoleAdmin, serviceTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/products/%d/%s", c.httpAddr, productID, action)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return service.ErrProductNotFound
+	case http.StatusConflict:
+		return service.ErrInsufficientStock
+	default:
+		return fmt.Errorf("product service returned status %d", resp.StatusCode)
+	}
+}
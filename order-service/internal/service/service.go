@@ -0,0 +1,320 @@
+// Package service holds the order business logic shared by the HTTP and
+// gRPC handlers.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrNotFound is returned when an order does not exist.
+var ErrNotFound = errors.New("order not found")
+
+// ErrEmptyOrder is returned when an order is created with no items.
+var ErrEmptyOrder = errors.New("order must contain at least one item")
+
+// ErrProductNotFound is returned when an item references a product the
+// product service doesn't know about.
+var ErrProductNotFound = errors.New("product not found")
+
+// ErrInsufficientStock is returned when an item's quantity exceeds the
+// product's current stock.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// ErrInvalidTransition is returned when a status transition is not
+// allowed from an order's current status.
+var ErrInvalidTransition = errors.New("invalid order status transition")
+
+// Order status values. An order starts at StatusPending and moves
+// through the transitions in validTransitions until it reaches one of
+// the terminal states (StatusCancelled, StatusRefunded) or completes.
+const (
+	StatusPending   = "pending"
+	StatusPaid      = "paid"
+	StatusShipped   = "shipped"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusRefunded  = "refunded"
+)
+
+// validTransitions enumerates the statuses an order may move to from
+// each status. Any pair not listed here is rejected with
+// ErrInvalidTransition.
+var validTransitions = map[string][]string{
+	StatusPending:   {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusShipped, StatusRefunded, StatusCancelled},
+	StatusShipped:   {StatusCompleted, StatusRefunded},
+	StatusCompleted: {StatusRefunded},
+}
+
+func canTransition(from, to string) bool {
+	for _, allowed := range validTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderStatusEvent is emitted whenever an order transitions between
+// statuses so interested systems (notifications, fulfillment, etc.) can
+// react without polling for changes.
+type OrderStatusEvent struct {
+	OrderID    int       `json:"order_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedAt  time.Time `json:"changed_at"`
+	Actor      string    `json:"actor"`
+}
+
+// EventPublisher emits order status transition events to interested
+// systems, e.g. a webhook notifier.
+type EventPublisher interface {
+	Publish(ctx context.Context, event OrderStatusEvent) error
+}
+
+// ProductClient resolves authoritative prices and holds stock on the
+// product service so the order service never trusts a client-supplied
+// total or an unchecked quantity.
+type ProductClient interface {
+	PriceByID(ctx context.Context, productID int) (float64, error)
+	Reserve(ctx context.Context, productID, quantity int) error
+	Release(ctx context.Context, productID, quantity int) error
+}
+
+type Item struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
+
+type Order struct {
+	ID        int       `json:"id"`
+	UserID    string    `json:"user_id"`
+	Items     []Item    `json:"items"`
+	Total     float64   `json:"total"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Service struct {
+	db       *sql.DB
+	products ProductClient
+	events   EventPublisher
+}
+
+func New(db *sql.DB, products ProductClient, events EventPublisher) *Service {
+	return &Service{db: db, products: products, events: events}
+}
+
+// Create recomputes the order total from authoritative product prices
+// and reserves stock for every item before persisting the order. If any
+// item fails to price or reserve, or the order fails to persist, every
+// reservation made so far is released and the order is not created.
+func (s *Service) Create(ctx context.Context, userID string, items []Item) (Order, error) {
+	if len(items) == 0 {
+		return Order{}, ErrEmptyOrder
+	}
+
+	var total float64
+	reserved := make([]Item, 0, len(items))
+	for _, item := range items {
+		price, err := s.products.PriceByID(ctx, item.ProductID)
+		if err != nil {
+			s.releaseAll(ctx, reserved)
+			return Order{}, err
+		}
+
+		if err := s.products.Reserve(ctx, item.ProductID, item.Quantity); err != nil {
+			s.releaseAll(ctx, reserved)
+			return Order{}, err
+		}
+		reserved = append(reserved, item)
+		total += price * float64(item.Quantity)
+	}
+
+	order, err := s.persist(userID, items, total)
+	if err != nil {
+		s.releaseAll(ctx, reserved)
+		return Order{}, err
+	}
+
+	return order, nil
+}
+
+func (s *Service) persist(userID string, items []Item, total float64) (Order, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Order{}, err
+	}
+	defer tx.Rollback()
+
+	var orderID int
+	err = tx.QueryRow(
+		"INSERT INTO orders (user_id, total, status) VALUES ($1, $2, $3) RETURNING id",
+		userID, total, StatusPending,
+	).Scan(&orderID)
+	if err != nil {
+		return Order{}, err
+	}
+
+	for _, item := range items {
+		if _, err := tx.Exec(
+			"INSERT INTO order_items (order_id, product_id, quantity) VALUES ($1, $2, $3)",
+			orderID, item.ProductID, item.Quantity,
+		); err != nil {
+			return Order{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Order{}, err
+	}
+
+	return Order{
+		ID:        orderID,
+		UserID:    userID,
+		Items:     items,
+		Total:     total,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Transition moves an order to a new status if that transition is valid
+// from its current status, recording the change in
+// order_status_history and publishing an event. Invalid transitions
+// (e.g. shipping an order that hasn't been paid) return
+// ErrInvalidTransition.
+func (s *Service) Transition(ctx context.Context, orderID int, to, actor string) (Order, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Order{}, err
+	}
+	defer tx.Rollback()
+
+	var from string
+	err = tx.QueryRow("SELECT status FROM orders WHERE id = $1 FOR UPDATE", orderID).Scan(&from)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Order{}, ErrNotFound
+	} else if err != nil {
+		return Order{}, err
+	}
+
+	if !canTransition(from, to) {
+		return Order{}, ErrInvalidTransition
+	}
+
+	if _, err := tx.Exec("UPDATE orders SET status = $1 WHERE id = $2", to, orderID); err != nil {
+		return Order{}, err
+	}
+
+	changedAt := time.Now()
+	if _, err := tx.Exec(
+		"INSERT INTO order_status_history (order_id, from_status, to_status, changed_at, actor) VALUES ($1, $2, $3, $4, $5)",
+		orderID, from, to, changedAt, actor,
+	); err != nil {
+		return Order{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Order{}, err
+	}
+
+	order, err := s.Get(orderID)
+	if err != nil {
+		return Order{}, err
+	}
+
+	event := OrderStatusEvent{OrderID: orderID, FromStatus: from, ToStatus: to, ChangedAt: changedAt, Actor: actor}
+	if err := s.events.Publish(ctx, event); err != nil {
+		log.Printf("failed to publish order status event for order %d: %v", orderID, err)
+	}
+
+	return order, nil
+}
+
+// releaseAll best-effort releases stock reservations for an order that
+// did not make it to a committed state. Failures are logged rather than
+// returned since the caller is already on an error path.
+func (s *Service) releaseAll(ctx context.Context, items []Item) {
+	for _, item := range items {
+		if err := s.products.Release(ctx, item.ProductID, item.Quantity); err != nil {
+			log.Printf("failed to release reservation for product %d: %v", item.ProductID, err)
+		}
+	}
+}
+
+func (s *Service) ListByUser(userID string) ([]Order, error) {
+	rows, err := s.db.Query(
+		"SELECT id, user_id, total, status, created_at FROM orders WHERE user_id = $1 ORDER BY created_at DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []Order{}
+	for rows.Next() {
+		var order Order
+		if err := rows.Scan(&order.ID, &order.UserID, &order.Total, &order.Status, &order.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		items, err := s.itemsForOrder(order.ID)
+		if err != nil {
+			return nil, err
+		}
+		order.Items = items
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+func (s *Service) Get(orderID int) (Order, error) {
+	var order Order
+	err := s.db.QueryRow(
+		"SELECT id, user_id, total, status, created_at FROM orders WHERE id = $1",
+		orderID,
+	).Scan(&order.ID, &order.UserID, &order.Total, &order.Status, &order.CreatedAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return Order{}, ErrNotFound
+	} else if err != nil {
+		return Order{}, err
+	}
+
+	items, err := s.itemsForOrder(order.ID)
+	if err != nil {
+		return Order{}, err
+	}
+	order.Items = items
+
+	return order, nil
+}
+
+func (s *Service) itemsForOrder(orderID int) ([]Item, error) {
+	rows, err := s.db.Query(
+		"SELECT product_id, quantity FROM order_items WHERE order_id = $1",
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Item{}
+	for rows.Next() {
+		var item Item
+		if err := rows.Scan(&item.ProductID, &item.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,51 @@
+// Package webhook notifies external listeners about order status
+// transitions via a configured HTTP endpoint.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/internal/service"
+)
+
+// Notifier posts order status transition events to a configured URL.
+// If no URL is configured, events are logged instead of sent.
+type Notifier struct {
+	url    string
+	client *http.Client
+}
+
+func New(url string) *Notifier {
+	return &Notifier{url: url, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (n *Notifier) Publish(ctx context.Context, event service.OrderStatusEvent) error {
+	if n.url == "" {
+		log.Printf("order %d transitioned %s -> %s (actor=%s)", event.OrderID, event.FromStatus, event.ToStatus, event.Actor)
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
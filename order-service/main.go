@@ -1,37 +1,49 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+	"github.com/ivanrbnc/k8s-ecommerce/internal/observability"
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/internal/productclient"
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/internal/webhook"
+	"github.com/ivanrbnc/k8s-ecommerce/order-service/orderpb"
 )
 
-type OrderItem struct {
-	ProductID int `json:"product_id"`
-	Quantity  int `json:"quantity"`
-}
-
-type Order struct {
-	ID        int         `json:"id"`
-	UserID    string      `json:"user_id"`
-	Items     []OrderItem `json:"items"`
-	Total     float64     `json:"total"`
-	Status    string      `json:"status"`
-	CreatedAt time.Time   `json:"created_at"`
-}
+const serviceName = "order-service"
 
-var db *sql.DB
+var (
+	db  *sql.DB
+	svc *service.Service
+)
 
 func main() {
 	var err error
 
+	observability.SetupLogging(serviceName)
+
+	shutdownTracing, err := observability.InitTracing(context.Background(), serviceName)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Database connection
 	dbHost := os.Getenv("DB_HOST")
 	dbPort := os.Getenv("DB_PORT")
@@ -79,12 +91,63 @@ func main() {
 	// Create tables
 	createTables()
 
+	productServiceGRPCAddr := os.Getenv("PRODUCT_SERVICE_GRPC_ADDR")
+	if productServiceGRPCAddr == "" {
+		productServiceGRPCAddr = "product-service:9001"
+	}
+	productServiceHTTPAddr := os.Getenv("PRODUCT_SERVICE_HTTP_ADDR")
+	if productServiceHTTPAddr == "" {
+		productServiceHTTPAddr = "http://product-service:8001"
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "dev-secret"
+	}
+
+	products, err := productclient.Dial(productServiceGRPCAddr, productServiceHTTPAddr, jwtSecret)
+	if err != nil {
+		log.Fatalf("Failed to connect to product service: %v", err)
+	}
+
+	notifier := webhook.New(os.Getenv("ORDER_WEBHOOK_URL"))
+
+	svc = service.New(db, products, notifier)
+
 	router := mux.NewRouter()
+	router.Use(observability.Middleware(serviceName))
 
 	router.HandleFunc("/health", healthHandler).Methods("GET")
-	router.HandleFunc("/orders", createOrderHandler).Methods("POST")
-	router.HandleFunc("/orders/{userId}", getUserOrdersHandler).Methods("GET")
-	router.HandleFunc("/orders/detail/{orderId}", getOrderDetailHandler).Methods("GET")
+	router.Handle("/metrics", observability.Handler()).Methods("GET")
+
+	ordersRouter := router.PathPrefix("/orders").Subrouter()
+	ordersRouter.Use(auth.Middleware(jwtSecret))
+	ordersRouter.HandleFunc("", createOrderHandler).Methods("POST")
+	ordersRouter.HandleFunc("/{userId}", getUserOrdersHandler).Methods("GET")
+	ordersRouter.HandleFunc("/detail/{orderId}", getOrderDetailHandler).Methods("GET")
+	ordersRouter.Handle("/{orderId}/pay", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(payHandler))).Methods("POST")
+	ordersRouter.Handle("/{orderId}/ship", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(shipHandler))).Methods("POST")
+	ordersRouter.Handle("/{orderId}/complete", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(completeHandler))).Methods("POST")
+	ordersRouter.Handle("/{orderId}/cancel", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(cancelHandler))).Methods("POST")
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9003"
+	}
+
+	go func() {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+		}
+		grpcServer := grpc.NewServer(
+			grpc.UnaryInterceptor(auth.UnaryServerInterceptor(jwtSecret)),
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		)
+		orderpb.RegisterOrderServiceServer(grpcServer, newGRPCServer(svc))
+		log.Printf("Order Service gRPC server starting on port %s...", grpcPort)
+		log.Fatal(grpcServer.Serve(lis))
+	}()
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -110,6 +173,14 @@ func createTables() {
 			product_id INTEGER NOT NULL,
 			quantity INTEGER NOT NULL
 		)`,
+		`CREATE TABLE IF NOT EXISTS order_status_history (
+			id SERIAL PRIMARY KEY,
+			order_id INTEGER REFERENCES orders(id) ON DELETE CASCADE,
+			from_status VARCHAR(50) NOT NULL,
+			to_status VARCHAR(50) NOT NULL,
+			changed_at TIMESTAMP NOT NULL,
+			actor VARCHAR(255) NOT NULL
+		)`,
 	}
 
 	for _, query := range queries {
@@ -142,9 +213,7 @@ func createOrderHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	var orderRequest struct {
-		UserID string      `json:"user_id"`
-		Items  []OrderItem `json:"items"`
-		Total  float64     `json:"total"`
+		Items []service.Item `json:"items"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&orderRequest); err != nil {
@@ -153,62 +222,30 @@ func createOrderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if orderRequest.UserID == "" || len(orderRequest.Items) == 0 {
+	if len(orderRequest.Items) == 0 {
 		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": "User ID and items are required"})
+		json.NewEncoder(w).Encode(map[string]string{"error": "Items are required"})
 		return
 	}
 
-	// Start transaction
-	tx, err := db.Begin()
+	userID := auth.UserID(r.Context())
+	order, err := svc.Create(r.Context(), userID, orderRequest.Items)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to create order"})
-		return
-	}
-	defer tx.Rollback()
-
-	// Insert order
-	var orderID int
-	err = tx.QueryRow(
-		"INSERT INTO orders (user_id, total, status) VALUES ($1, $2, $3) RETURNING id",
-		orderRequest.UserID, orderRequest.Total, "pending",
-	).Scan(&orderID)
-
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to create order"})
-		return
-	}
-
-	// Insert order items
-	for _, item := range orderRequest.Items {
-		_, err := tx.Exec(
-			"INSERT INTO order_items (order_id, product_id, quantity) VALUES ($1, $2, $3)",
-			orderID, item.ProductID, item.Quantity,
-		)
-		if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProductNotFound):
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "One or more products do not exist"})
+		case errors.Is(err, service.ErrInsufficientStock):
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Insufficient stock for one or more products"})
+		default:
 			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to create order items"})
-			return
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to create order"})
 		}
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to commit order"})
 		return
 	}
 
-	order := Order{
-		ID:        orderID,
-		UserID:    orderRequest.UserID,
-		Items:     orderRequest.Items,
-		Total:     orderRequest.Total,
-		Status:    "pending",
-		CreatedAt: time.Now(),
-	}
+	observability.OrdersCreatedTotal.Inc()
 
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(order)
@@ -219,63 +256,40 @@ func getUserOrdersHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["userId"]
 
-	rows, err := db.Query(
-		"SELECT id, user_id, total, status, created_at FROM orders WHERE user_id = $1 ORDER BY created_at DESC",
-		userID,
-	)
+	if auth.UserID(r.Context()) != userID && auth.Role(r.Context()) != auth.RoleAdmin {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Forbidden"})
+		return
+	}
+
+	orders, err := svc.ListByUser(userID)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve orders"})
 		return
 	}
-	defer rows.Close()
-
-	orders := []Order{}
-	for rows.Next() {
-		var order Order
-		err := rows.Scan(&order.ID, &order.UserID, &order.Total, &order.Status, &order.CreatedAt)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to parse orders"})
-			return
-		}
-
-		// Get order items
-		itemRows, err := db.Query(
-			"SELECT product_id, quantity FROM order_items WHERE order_id = $1",
-			order.ID,
-		)
-		if err != nil {
-			continue
-		}
-
-		items := []OrderItem{}
-		for itemRows.Next() {
-			var item OrderItem
-			itemRows.Scan(&item.ProductID, &item.Quantity)
-			items = append(items, item)
-		}
-		itemRows.Close()
-
-		order.Items = items
-		orders = append(orders, order)
-	}
 
 	json.NewEncoder(w).Encode(orders)
 }
 
+func parseOrderID(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
 func getOrderDetailHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	vars := mux.Vars(r)
-	orderID := vars["orderId"]
+	orderIDStr := vars["orderId"]
 
-	var order Order
-	err := db.QueryRow(
-		"SELECT id, user_id, total, status, created_at FROM orders WHERE id = $1",
-		orderID,
-	).Scan(&order.ID, &order.UserID, &order.Total, &order.Status, &order.CreatedAt)
+	orderID, err := parseOrderID(orderIDStr)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid order ID"})
+		return
+	}
 
-	if err == sql.ErrNoRows {
+	order, err := svc.Get(orderID)
+	if errors.Is(err, service.ErrNotFound) {
 		w.WriteHeader(http.StatusNotFound)
 		json.NewEncoder(w).Encode(map[string]string{"error": "Order not found"})
 		return
@@ -285,25 +299,54 @@ func getOrderDetailHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get order items
-	rows, err := db.Query(
-		"SELECT product_id, quantity FROM order_items WHERE order_id = $1",
-		order.ID,
-	)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve order items"})
+	if auth.UserID(r.Context()) != order.UserID && auth.Role(r.Context()) != auth.RoleAdmin {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Forbidden"})
 		return
 	}
-	defer rows.Close()
 
-	items := []OrderItem{}
-	for rows.Next() {
-		var item OrderItem
-		rows.Scan(&item.ProductID, &item.Quantity)
-		items = append(items, item)
+	json.NewEncoder(w).Encode(order)
+}
+
+func payHandler(w http.ResponseWriter, r *http.Request) {
+	transitionHandler(w, r, service.StatusPaid)
+}
+
+func shipHandler(w http.ResponseWriter, r *http.Request) {
+	transitionHandler(w, r, service.StatusShipped)
+}
+
+func completeHandler(w http.ResponseWriter, r *http.Request) {
+	transitionHandler(w, r, service.StatusCompleted)
+}
+
+func cancelHandler(w http.ResponseWriter, r *http.Request) {
+	transitionHandler(w, r, service.StatusCancelled)
+}
+
+func transitionHandler(w http.ResponseWriter, r *http.Request, to string) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(r)
+
+	orderID, err := parseOrderID(vars["orderId"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid order ID"})
+		return
 	}
 
-	order.Items = items
-	json.NewEncoder(w).Encode(order)
+	order, err := svc.Transition(r.Context(), orderID, to, auth.UserID(r.Context()))
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Order not found"})
+	case errors.Is(err, service.ErrInvalidTransition):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid order status transition"})
+	case err != nil:
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to update order status"})
+	default:
+		json.NewEncoder(w).Encode(order)
+	}
 }
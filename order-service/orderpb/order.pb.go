@@ -0,0 +1,75 @@
+// Hand-written to mirror what protoc-gen-go would emit from proto/order.proto.
+// Regenerate for real with proto/generate.sh once protoc is available
+// in this environment; this will otherwise silently drift from the
+// .proto definition.
+
+package orderpb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+)
+
+type OrderItem struct {
+	ProductId int32 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (m *OrderItem) Reset()         { *m = OrderItem{} }
+func (m *OrderItem) String() string { return proto.CompactTextString(m) }
+func (*OrderItem) ProtoMessage()    {}
+
+type Order struct {
+	Id        int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    string               `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items     []*OrderItem         `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Total     float64              `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
+	Status    string               `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (m *Order) Reset()         { *m = Order{} }
+func (m *Order) String() string { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()    {}
+
+type CreateOrderRequest struct {
+	UserId string       `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*OrderItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (m *CreateOrderRequest) Reset()         { *m = CreateOrderRequest{} }
+func (m *CreateOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateOrderRequest) ProtoMessage()    {}
+
+type GetOrderRequest struct {
+	OrderId int32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+}
+
+func (m *GetOrderRequest) Reset()         { *m = GetOrderRequest{} }
+func (m *GetOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderRequest) ProtoMessage()    {}
+
+type ListByUserRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (m *ListByUserRequest) Reset()         { *m = ListByUserRequest{} }
+func (m *ListByUserRequest) String() string { return proto.CompactTextString(m) }
+func (*ListByUserRequest) ProtoMessage()    {}
+
+type ListByUserResponse struct {
+	Orders []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+}
+
+func (m *ListByUserResponse) Reset()         { *m = ListByUserResponse{} }
+func (m *ListByUserResponse) String() string { return proto.CompactTextString(m) }
+func (*ListByUserResponse) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterType((*OrderItem)(nil), "order.OrderItem")
+	proto.RegisterType((*Order)(nil), "order.Order")
+	proto.RegisterType((*CreateOrderRequest)(nil), "order.CreateOrderRequest")
+	proto.RegisterType((*GetOrderRequest)(nil), "order.GetOrderRequest")
+	proto.RegisterType((*ListByUserRequest)(nil), "order.ListByUserRequest")
+	proto.RegisterType((*ListByUserResponse)(nil), "order.ListByUserResponse")
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ivanrbnc/k8s-ecommerce/product-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/product-service/productpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer adapts the shared product service to the generated
+// ProductService gRPC interface.
+type grpcServer struct {
+	productpb.UnimplementedProductServiceServer
+	svc *service.Service
+}
+
+func newGRPCServer(svc *service.Service) *grpcServer {
+	return &grpcServer{svc: svc}
+}
+
+func (s *grpcServer) List(ctx context.Context, req *productpb.ListProductsRequest) (*productpb.ListProductsResponse, error) {
+	products, err := s.svc.List(service.ListParams{Limit: 1000})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	resp := &productpb.ListProductsResponse{Products: make([]*productpb.Product, 0, len(products))}
+	for _, p := range products {
+		resp.Products = append(resp.Products, toPB(p))
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) GetByID(ctx context.Context, req *productpb.GetProductByIDRequest) (*productpb.Product, error) {
+	p, err := s.svc.GetByID(int(req.Id))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toPB(p), nil
+}
+
+func toGRPCError(err error) error {
+	if errors.Is(err, service.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toPB(p service.Product) *productpb.Product {
+	return &productpb.Product{
+		Id:          int32(p.ID),
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Stock:       int32(p.Stock),
+	}
+}
@@ -0,0 +1,277 @@
+// Package service holds the product catalog business logic shared by the
+// HTTP and gRPC handlers.
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when a product lookup does not match any
+// known product.
+var ErrNotFound = errors.New("product not found")
+
+// ErrCategoryNotFound is returned when a category lookup does not match
+// any known category.
+var ErrCategoryNotFound = errors.New("category not found")
+
+// ErrInsufficientStock is returned when a reservation asks for more
+// units than are currently available.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+type Product struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	Stock       int     `json:"stock"`
+	CategoryID  int     `json:"category_id,omitempty"`
+}
+
+type Category struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListParams filters and paginates the product search endpoint. A zero
+// value lists products in ascending id order, 20 at a time (a Limit of
+// zero or less means 20).
+type ListParams struct {
+	Query    string
+	MinPrice *float64
+	MaxPrice *float64
+	Sort     string
+	Limit    int
+	Offset   int
+}
+
+type Service struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// List returns products matching params, full-text searching name and
+// description when Query is set.
+func (s *Service) List(params ListParams) ([]Product, error) {
+	query := "SELECT id, name, description, price, stock, category_id FROM products WHERE 1=1"
+	var args []interface{}
+
+	if params.Query != "" {
+		args = append(args, params.Query)
+		query += fmt.Sprintf(" AND to_tsvector('english', name || ' ' || coalesce(description, '')) @@ plainto_tsquery('english', $%d)", len(args))
+	}
+	if params.MinPrice != nil {
+		args = append(args, *params.MinPrice)
+		query += fmt.Sprintf(" AND price >= $%d", len(args))
+	}
+	if params.MaxPrice != nil {
+		args = append(args, *params.MaxPrice)
+		query += fmt.Sprintf(" AND price <= $%d", len(args))
+	}
+
+	switch params.Sort {
+	case "price_asc":
+		query += " ORDER BY price ASC"
+	case "price_desc":
+		query += " ORDER BY price DESC"
+	default:
+		query += " ORDER BY id ASC"
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" LIMIT $%d", len(args))
+	args = append(args, params.Offset)
+	query += fmt.Sprintf(" OFFSET $%d", len(args))
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []Product{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+func (s *Service) GetByID(id int) (Product, error) {
+	row := s.db.QueryRow("SELECT id, name, description, price, stock, category_id FROM products WHERE id = $1", id)
+	p, err := scanProduct(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Product{}, ErrNotFound
+	} else if err != nil {
+		return Product{}, err
+	}
+	return p, nil
+}
+
+func (s *Service) Create(p Product) (Product, error) {
+	err := s.db.QueryRow(
+		"INSERT INTO products (name, description, price, stock, category_id) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		p.Name, p.Description, p.Price, p.Stock, nullableID(p.CategoryID),
+	).Scan(&p.ID)
+	if err != nil {
+		return Product{}, err
+	}
+	return p, nil
+}
+
+func (s *Service) Update(id int, p Product) (Product, error) {
+	res, err := s.db.Exec(
+		"UPDATE products SET name = $1, description = $2, price = $3, stock = $4, category_id = $5 WHERE id = $6",
+		p.Name, p.Description, p.Price, p.Stock, nullableID(p.CategoryID), id,
+	)
+	if err != nil {
+		return Product{}, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Product{}, err
+	}
+	if affected == 0 {
+		return Product{}, ErrNotFound
+	}
+	p.ID = id
+	return p, nil
+}
+
+func (s *Service) Delete(id int) error {
+	res, err := s.db.Exec("DELETE FROM products WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Reserve atomically decrements stock for a product, failing with
+// ErrInsufficientStock if the current stock can't cover quantity. It is
+// the first phase of the order service's checkout flow; callers must
+// call Release if the order is not ultimately committed.
+func (s *Service) Reserve(id, quantity int) error {
+	res, err := s.db.Exec("UPDATE products SET stock = stock - $1 WHERE id = $2 AND stock >= $1", quantity, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 1 {
+		return nil
+	}
+
+	if _, err := s.GetByID(id); errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	return ErrInsufficientStock
+}
+
+// Release restores stock reserved for an order that was rolled back.
+func (s *Service) Release(id, quantity int) error {
+	res, err := s.db.Exec("UPDATE products SET stock = stock + $1 WHERE id = $2", quantity, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *Service) Categories() ([]Category, error) {
+	rows, err := s.db.Query("SELECT id, name, description FROM categories ORDER BY id ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []Category{}
+	for rows.Next() {
+		var c Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, nil
+}
+
+func (s *Service) CategoryByID(id int) (Category, error) {
+	var c Category
+	err := s.db.QueryRow("SELECT id, name, description FROM categories WHERE id = $1", id).Scan(&c.ID, &c.Name, &c.Description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Category{}, ErrCategoryNotFound
+	} else if err != nil {
+		return Category{}, err
+	}
+	return c, nil
+}
+
+func (s *Service) ProductsByCategory(categoryID int) ([]Product, error) {
+	rows, err := s.db.Query("SELECT id, name, description, price, stock, category_id FROM products WHERE category_id = $1 ORDER BY id ASC", categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []Product{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows, letting
+// scanProduct back GetByID and the row-by-row list queries.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProduct(row rowScanner) (Product, error) {
+	var p Product
+	var categoryID sql.NullInt64
+	if err := row.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Stock, &categoryID); err != nil {
+		return Product{}, err
+	}
+	if categoryID.Valid {
+		p.CategoryID = int(categoryID.Int64)
+	}
+	return p, nil
+}
+
+func nullableID(id int) sql.NullInt64 {
+	if id == 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(id), Valid: true}
+}
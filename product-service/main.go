@@ -1,37 +1,138 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
+	_ "github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+	"github.com/ivanrbnc/k8s-ecommerce/internal/observability"
+	"github.com/ivanrbnc/k8s-ecommerce/product-service/internal/service"
+	"github.com/ivanrbnc/k8s-ecommerce/product-service/productpb"
 )
 
-type Product struct {
-	ID          int     `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Stock       int     `json:"stock"`
-}
+const serviceName = "product-service"
 
-var products = []Product{
-	{ID: 1, Name: "Laptop", Description: "High-performance laptop", Price: 999.99, Stock: 10},
-	{ID: 2, Name: "Mouse", Description: "Wireless mouse", Price: 29.99, Stock: 50},
-	{ID: 3, Name: "Keyboard", Description: "Mechanical keyboard", Price: 79.99, Stock: 30},
-	{ID: 4, Name: "Monitor", Description: "4K Monitor", Price: 399.99, Stock: 15},
-	{ID: 5, Name: "Headphones", Description: "Noise-cancelling headphones", Price: 199.99, Stock: 25},
-}
+var (
+	db  *sql.DB
+	svc *service.Service
+)
 
 func main() {
+	var err error
+
+	observability.SetupLogging(serviceName)
+
+	shutdownTracing, err := observability.InitTracing(context.Background(), serviceName)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// Database connection
+	dbHost := os.Getenv("DB_HOST")
+	dbPort := os.Getenv("DB_PORT")
+	dbUser := os.Getenv("DB_USER")
+	dbPassword := os.Getenv("DB_PASSWORD")
+	dbName := os.Getenv("DB_NAME")
+
+	if dbHost == "" {
+		dbHost = "localhost"
+	}
+	if dbPort == "" {
+		dbPort = "5432"
+	}
+	if dbUser == "" {
+		dbUser = "postgres"
+	}
+	if dbPassword == "" {
+		dbPassword = "postgres"
+	}
+	if dbName == "" {
+		dbName = "products"
+	}
+
+	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		dbHost, dbPort, dbUser, dbPassword, dbName)
+
+	// Retry connection logic
+	for i := 0; i < 10; i++ {
+		db, err = sql.Open("postgres", connStr)
+		if err == nil {
+			err = db.Ping()
+			if err == nil {
+				log.Println("Connected to PostgreSQL successfully")
+				break
+			}
+		}
+		log.Printf("Failed to connect to database (attempt %d/10): %v", i+1, err)
+		time.Sleep(3 * time.Second)
+	}
+
+	if err != nil {
+		log.Fatalf("Could not connect to database after 10 attempts: %v", err)
+	}
+
+	createTables()
+
+	svc = service.New(db)
+
+	seedIfEmpty()
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "dev-secret"
+	}
+
 	router := mux.NewRouter()
+	router.Use(observability.Middleware(serviceName))
 
 	router.HandleFunc("/health", healthHandler).Methods("GET")
+	router.Handle("/metrics", observability.Handler()).Methods("GET")
 	router.HandleFunc("/products", getProductsHandler).Methods("GET")
 	router.HandleFunc("/products/{id}", getProductByIDHandler).Methods("GET")
+	router.HandleFunc("/categories", listCategoriesHandler).Methods("GET")
+	router.HandleFunc("/categories/{id}/products", categoryProductsHandler).Methods("GET")
+
+	// Catalog mutations and the stock-reserve/release calls used by the
+	// order service's checkout saga are admin-only, the same way
+	// order-service gates its own status-transition endpoints.
+	adminRouter := router.PathPrefix("/products").Subrouter()
+	adminRouter.Use(auth.Middleware(jwtSecret))
+	adminRouter.Handle("", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(createProductHandler))).Methods("POST")
+	adminRouter.Handle("/{id}", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(updateProductHandler))).Methods("PUT")
+	adminRouter.Handle("/{id}", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(deleteProductHandler))).Methods("DELETE")
+	adminRouter.Handle("/{id}/reserve", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(reserveHandler))).Methods("POST")
+	adminRouter.Handle("/{id}/release", auth.RequireRole(auth.RoleAdmin, http.HandlerFunc(releaseHandler))).Methods("POST")
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9001"
+	}
+
+	go func() {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+		}
+		grpcServer := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+		productpb.RegisterProductServiceServer(grpcServer, newGRPCServer(svc))
+		log.Printf("Product Service gRPC server starting on port %s...", grpcPort)
+		log.Fatal(grpcServer.Serve(lis))
+	}()
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -42,16 +143,158 @@ func main() {
 	log.Fatal(http.ListenAndServe(":"+port, router))
 }
 
+func createTables() {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS categories (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			description TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS products (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			description TEXT,
+			price DECIMAL(10, 2) NOT NULL,
+			stock INTEGER NOT NULL DEFAULT 0,
+			category_id INTEGER REFERENCES categories(id)
+		)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			log.Fatalf("Failed to create table: %v", err)
+		}
+	}
+
+	log.Println("Database tables created successfully")
+}
+
+// seedIfEmpty populates the catalog with its original demo data the
+// first time the service runs against a fresh database.
+func seedIfEmpty() {
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM products").Scan(&count); err != nil {
+		log.Fatalf("Failed to check product count: %v", err)
+	}
+	if count > 0 {
+		return
+	}
+
+	var categoryID int
+	err := db.QueryRow(
+		"INSERT INTO categories (name, description) VALUES ($1, $2) RETURNING id",
+		"Electronics", "Computers, peripherals, and accessories",
+	).Scan(&categoryID)
+	if err != nil {
+		log.Fatalf("Failed to seed categories: %v", err)
+	}
+
+	seedProducts := []service.Product{
+		{Name: "Laptop", Description: "High-performance laptop", Price: 999.99, Stock: 10, CategoryID: categoryID},
+		{Name: "Mouse", Description: "Wireless mouse", Price: 29.99, Stock: 50, CategoryID: categoryID},
+		{Name: "Keyboard", Description: "Mechanical keyboard", Price: 79.99, Stock: 30, CategoryID: categoryID},
+		{Name: "Monitor", Description: "4K Monitor", Price: 399.99, Stock: 15, CategoryID: categoryID},
+		{Name: "Headphones", Description: "Noise-cancelling headphones", Price: 199.99, Stock: 25, CategoryID: categoryID},
+	}
+	for _, p := range seedProducts {
+		created, err := svc.Create(p)
+		if err != nil {
+			log.Fatalf("Failed to seed products: %v", err)
+		}
+		updateStockGauge(created.ID)
+	}
+
+	log.Println("Seeded initial product catalog")
+}
+
+// updateStockGauge refreshes the product_stock gauge for a product
+// after a mutation. Failures are logged rather than returned since the
+// caller has already responded to its own request.
+func updateStockGauge(id int) {
+	p, err := svc.GetByID(id)
+	if err != nil {
+		log.Printf("failed to refresh stock gauge for product %d: %v", id, err)
+		return
+	}
+	observability.ProductStock.WithLabelValues(strconv.Itoa(p.ID)).Set(float64(p.Stock))
+}
+
 func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "healthy", "service": "product-service"})
+
+	status := "healthy"
+	if err := db.Ping(); err != nil {
+		status = "unhealthy"
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"status": status, "service": "product-service"})
 }
 
+// getProductsHandler lists products, optionally full-text searching
+// name/description with q and filtering/sorting/paginating with
+// min_price, max_price, sort (price_asc|price_desc), limit and offset.
 func getProductsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+
+	q := r.URL.Query()
+	params := service.ListParams{
+		Query: q.Get("q"),
+		Sort:  q.Get("sort"),
+	}
+	if v := q.Get("min_price"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			params.MinPrice = &f
+		}
+	}
+	if v := q.Get("max_price"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			params.MaxPrice = &f
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			params.Limit = n
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			params.Offset = n
+		}
+	}
+
+	products, err := svc.List(params)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve products"})
+		return
+	}
+
 	json.NewEncoder(w).Encode(products)
 }
 
+func createProductHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var p service.Product
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	created, err := svc.Create(p)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to create product"})
+		return
+	}
+
+	updateStockGauge(created.ID)
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
 func getProductByIDHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	vars := mux.Vars(r)
@@ -63,13 +306,190 @@ func getProductByIDHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	for _, product := range products {
-		if product.ID == id {
-			json.NewEncoder(w).Encode(product)
-			return
+	product, err := svc.GetByID(id)
+	if errors.Is(err, service.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Product not found"})
+		return
+	}
+
+	json.NewEncoder(w).Encode(product)
+}
+
+func updateProductHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid product ID"})
+		return
+	}
+
+	var p service.Product
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	updated, err := svc.Update(id, p)
+	if errors.Is(err, service.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Product not found"})
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to update product"})
+		return
+	}
+
+	updateStockGauge(updated.ID)
+
+	json.NewEncoder(w).Encode(updated)
+}
+
+func deleteProductHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid product ID"})
+		return
+	}
+
+	if err := svc.Delete(id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Product not found"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to delete product"})
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Product deleted"})
+}
+
+func listCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	categories, err := svc.Categories()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve categories"})
+		return
+	}
+
+	json.NewEncoder(w).Encode(categories)
+}
+
+func categoryProductsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid category ID"})
+		return
+	}
+
+	if _, err := svc.CategoryByID(id); errors.Is(err, service.ErrCategoryNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Category not found"})
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve category"})
+		return
+	}
+
+	products, err := svc.ProductsByCategory(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve products"})
+		return
+	}
+
+	json.NewEncoder(w).Encode(products)
+}
+
+// stockChangeRequest is the body accepted by the reserve/release
+// endpoints used by the order service's checkout flow.
+type stockChangeRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+func reserveHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid product ID"})
+		return
+	}
+
+	var body stockChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	if err := svc.Reserve(id, body.Quantity); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Product not found"})
+		case errors.Is(err, service.ErrInsufficientStock):
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Insufficient stock"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to reserve stock"})
+		}
+		return
+	}
+
+	updateStockGauge(id)
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Stock reserved"})
+}
+
+func releaseHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid product ID"})
+		return
+	}
+
+	var body stockChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	if err := svc.Release(id, body.Quantity); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Product not found"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to release stock"})
 		}
+		return
 	}
 
-	w.WriteHeader(http.StatusNotFound)
-	json.NewEncoder(w).Encode(map[string]string{"error": "Product not found"})
+	updateStockGauge(id)
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Stock released"})
 }
@@ -0,0 +1,51 @@
+// Hand-written to mirror what protoc-gen-go would emit from proto/product.proto.
+// Regenerate for real with proto/generate.sh once protoc is available
+// in this environment; this will otherwise silently drift from the
+// .proto definition.
+
+package productpb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+type Product struct {
+	Id          int32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Stock       int32   `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+}
+
+func (m *Product) Reset()         { *m = Product{} }
+func (m *Product) String() string { return proto.CompactTextString(m) }
+func (*Product) ProtoMessage()    {}
+
+type ListProductsRequest struct{}
+
+func (m *ListProductsRequest) Reset()         { *m = ListProductsRequest{} }
+func (m *ListProductsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListProductsRequest) ProtoMessage()    {}
+
+type ListProductsResponse struct {
+	Products []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+}
+
+func (m *ListProductsResponse) Reset()         { *m = ListProductsResponse{} }
+func (m *ListProductsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListProductsResponse) ProtoMessage()    {}
+
+type GetProductByIDRequest struct {
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *GetProductByIDRequest) Reset()         { *m = GetProductByIDRequest{} }
+func (m *GetProductByIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProductByIDRequest) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterType((*Product)(nil), "product.Product")
+	proto.RegisterType((*ListProductsRequest)(nil), "product.ListProductsRequest")
+	proto.RegisterType((*ListProductsResponse)(nil), "product.ListProductsResponse")
+	proto.RegisterType((*GetProductByIDRequest)(nil), "product.GetProductByIDRequest")
+}
@@ -0,0 +1,112 @@
+// Hand-written to mirror what protoc-gen-go-grpc would emit from proto/product.proto.
+// Regenerate for real with proto/generate.sh once protoc is available
+// in this environment; this will otherwise silently drift from the
+// .proto definition.
+
+package productpb
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+const (
+	ProductService_List_FullMethodName    = "/product.ProductService/List"
+	ProductService_GetByID_FullMethodName = "/product.ProductService/GetByID"
+)
+
+// ProductServiceClient is the client API for ProductService.
+type ProductServiceClient interface {
+	List(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	GetByID(ctx context.Context, in *GetProductByIDRequest, opts ...grpc.CallOption) (*Product, error)
+}
+
+type productServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProductServiceClient(cc grpc.ClientConnInterface) ProductServiceClient {
+	return &productServiceClient{cc}
+}
+
+func (c *productServiceClient) List(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	out := new(ListProductsResponse)
+	if err := c.cc.Invoke(ctx, ProductService_List_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetByID(ctx context.Context, in *GetProductByIDRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	if err := c.cc.Invoke(ctx, ProductService_GetByID_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProductServiceServer is the server API for ProductService.
+type ProductServiceServer interface {
+	List(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	GetByID(context.Context, *GetProductByIDRequest) (*Product, error)
+}
+
+// UnimplementedProductServiceServer can be embedded to have forward
+// compatible implementations.
+type UnimplementedProductServiceServer struct{}
+
+func (UnimplementedProductServiceServer) List(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method List not implemented")
+}
+
+func (UnimplementedProductServiceServer) GetByID(context.Context, *GetProductByIDRequest) (*Product, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetByID not implemented")
+}
+
+func RegisterProductServiceServer(s grpc.ServiceRegistrar, srv ProductServiceServer) {
+	s.RegisterService(&ProductService_ServiceDesc, srv)
+}
+
+func _ProductService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ProductService_List_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).List(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ProductService_GetByID_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetByID(ctx, req.(*GetProductByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var ProductService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "product.ProductService",
+	HandlerType: (*ProductServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "List", Handler: _ProductService_List_Handler},
+		{MethodName: "GetByID", Handler: _ProductService_GetByID_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/product.proto",
+}
@@ -0,0 +1,133 @@
+// Package service holds the user account business logic: registration,
+// authentication, and issuing the JWTs the rest of the system trusts.
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+)
+
+// ErrEmailTaken is returned when registering with an email that
+// already has an account.
+var ErrEmailTaken = errors.New("email already registered")
+
+// ErrInvalidCredentials is returned when a login's email/password
+// combination doesn't match a known account.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// ErrNotFound is returned when a user lookup does not match any known
+// account.
+var ErrNotFound = errors.New("user not found")
+
+// tokenTTL is how long an issued JWT remains valid.
+const tokenTTL = 24 * time.Hour
+
+type User struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Service struct {
+	db        *sql.DB
+	jwtSecret string
+}
+
+func New(db *sql.DB, jwtSecret string) *Service {
+	return &Service{db: db, jwtSecret: jwtSecret}
+}
+
+// Register creates a new account with a bcrypt-hashed password and the
+// default "user" role.
+func (s *Service) Register(email, password string) (User, error) {
+	return s.createUser(email, password, auth.RoleUser)
+}
+
+// SeedAdmin creates an account with the admin role for the given email
+// if no account with that email already exists. An existing account is
+// left unchanged, whatever its role. Register can only ever grant the
+// "user" role, so
+// this is the one path that provisions an account able to reach
+// admin-only endpoints such as the order service's status transitions.
+func (s *Service) SeedAdmin(email, password string) error {
+	_, err := s.createUser(email, password, auth.RoleAdmin)
+	if errors.Is(err, ErrEmailTaken) {
+		return nil
+	}
+	return err
+}
+
+func (s *Service) createUser(email, password, role string) (User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return User{}, err
+	}
+
+	var user User
+	err = s.db.QueryRow(
+		"INSERT INTO users (email, password_hash, role) VALUES ($1, $2, $3) RETURNING id, email, role, created_at",
+		email, string(hash), role,
+	).Scan(&user.ID, &user.Email, &user.Role, &user.CreatedAt)
+	if isUniqueViolation(err) {
+		return User{}, ErrEmailTaken
+	} else if err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+// Login verifies the email/password pair and, on success, returns a
+// signed JWT carrying the user's ID and role.
+func (s *Service) Login(email, password string) (string, error) {
+	var (
+		id           int
+		passwordHash string
+		role         string
+	)
+	err := s.db.QueryRow(
+		"SELECT id, password_hash, role FROM users WHERE email = $1",
+		email,
+	).Scan(&id, &passwordHash, &role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrInvalidCredentials
+	} else if err != nil {
+		return "", err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	return auth.GenerateToken(s.jwtSecret, strconv.Itoa(id), role, tokenTTL)
+}
+
+func (s *Service) Get(userID int) (User, error) {
+	var user User
+	err := s.db.QueryRow(
+		"SELECT id, email, role, created_at FROM users WHERE id = $1",
+		userID,
+	).Scan(&user.ID, &user.Email, &user.Role, &user.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ErrNotFound
+	} else if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	return false
+}
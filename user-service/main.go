@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	_ "github.com/lib/pq"
+
+	"github.com/ivanrbnc/k8s-ecommerce/internal/auth"
+	"github.com/ivanrbnc/k8s-ecommerce/internal/observability"
+	"github.com/ivanrbnc/k8s-ecommerce/user-service/internal/service"
+)
+
+const serviceName = "user-service"
+
+var (
+	db  *sql.DB
+	svc *service.Service
+)
+
+func main() {
+	var err error
+
+	observability.SetupLogging(serviceName)
+
+	shutdownTracing, err := observability.InitTracing(context.Background(), serviceName)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// Database connection
+	dbHost := os.Getenv("DB_HOST")
+	dbPort := os.Getenv("DB_PORT")
+	dbUser := os.Getenv("DB_USER")
+	dbPassword := os.Getenv("DB_PASSWORD")
+	dbName := os.Getenv("DB_NAME")
+
+	if dbHost == "" {
+		dbHost = "localhost"
+	}
+	if dbPort == "" {
+		dbPort = "5432"
+	}
+	if dbUser == "" {
+		dbUser = "postgres"
+	}
+	if dbPassword == "" {
+		dbPassword = "postgres"
+	}
+	if dbName == "" {
+		dbName = "users"
+	}
+
+	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		dbHost, dbPort, dbUser, dbPassword, dbName)
+
+	// Retry connection logic
+	for i := 0; i < 10; i++ {
+		db, err = sql.Open("postgres", connStr)
+		if err == nil {
+			err = db.Ping()
+			if err == nil {
+				log.Println("Connected to PostgreSQL successfully")
+				break
+			}
+		}
+		log.Printf("Failed to connect to database (attempt %d/10): %v", i+1, err)
+		time.Sleep(3 * time.Second)
+	}
+
+	if err != nil {
+		log.Fatalf("Could not connect to database after 10 attempts: %v", err)
+	}
+
+	createTables()
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "dev-secret"
+	}
+
+	svc = service.New(db, jwtSecret)
+
+	seedAdminIfConfigured()
+
+	router := mux.NewRouter()
+	router.Use(observability.Middleware(serviceName))
+
+	router.HandleFunc("/health", healthHandler).Methods("GET")
+	router.Handle("/metrics", observability.Handler()).Methods("GET")
+	router.HandleFunc("/register", registerHandler).Methods("POST")
+	router.HandleFunc("/login", loginHandler).Methods("POST")
+
+	meRouter := router.PathPrefix("/me").Subrouter()
+	meRouter.Use(auth.Middleware(jwtSecret))
+	meRouter.HandleFunc("", meHandler).Methods("GET")
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8004"
+	}
+
+	log.Printf("User Service starting on port %s...", port)
+	log.Fatal(http.ListenAndServe(":"+port, router))
+}
+
+func createTables() {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS users (
+		id SERIAL PRIMARY KEY,
+		email VARCHAR(255) UNIQUE NOT NULL,
+		password_hash VARCHAR(255) NOT NULL,
+		role VARCHAR(50) NOT NULL DEFAULT 'user',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Fatalf("Failed to create table: %v", err)
+	}
+	log.Println("Database tables created successfully")
+}
+
+// seedAdminIfConfigured provisions an admin account from ADMIN_EMAIL
+// and ADMIN_PASSWORD if both are set. Register only ever grants the
+// "user" role, so without this an admin-gated endpoint such as the
+// order service's pay/ship/complete/cancel handlers would be
+// unreachable by any account the system itself can create.
+func seedAdminIfConfigured() {
+	email := os.Getenv("ADMIN_EMAIL")
+	password := os.Getenv("ADMIN_PASSWORD")
+	if email == "" || password == "" {
+		return
+	}
+
+	if err := svc.SeedAdmin(email, password); err != nil {
+		log.Fatalf("Failed to seed admin account: %v", err)
+	}
+	log.Println("Admin account ready")
+}
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := db.Ping()
+	status := "healthy"
+	if err != nil {
+		status = "unhealthy"
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"status":  status,
+		"service": "user-service",
+	})
+}
+
+func registerHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	if req.Email == "" || req.Password == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Email and password are required"})
+		return
+	}
+
+	user, err := svc.Register(req.Email, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmailTaken):
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Email already registered"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to register user"})
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+func loginHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		return
+	}
+
+	token, err := svc.Login(req.Email, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials):
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Invalid email or password"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to log in"})
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+func meHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := strconv.Atoi(auth.UserID(r.Context()))
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid token"})
+		return
+	}
+
+	user, err := svc.Get(userID)
+	if errors.Is(err, service.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "User not found"})
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to retrieve user"})
+		return
+	}
+
+	json.NewEncoder(w).Encode(user)
+}